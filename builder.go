@@ -0,0 +1,324 @@
+package epp2json
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// BuilderError opisuje błąd pojedynczego pola napotkany podczas budowania faktury
+// lub jej pozycji; ParseHeader/ParseItem zwracają te błędy zamiast po cichu
+// podstawiać wartość zerową
+type BuilderError struct {
+	Field  string
+	Reason string
+}
+
+func (e *BuilderError) Error() string {
+	return fmt.Sprintf("pole %q: %s", e.Field, e.Reason)
+}
+
+// ValidationError opisuje naruszenie reguły walidacji już zbudowanej faktury
+type ValidationError struct {
+	Field  string
+	Reason string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("pole %q: %s", e.Field, e.Reason)
+}
+
+// InvoiceBuilder buduje Invoice krok po kroku, zbierając BuilderError dla
+// każdego niepoprawnego pola zamiast przerywać budowanie na pierwszym błędzie.
+// Daty Date, SaleDate i PaymentDate nie są walidowane: niepoprawny format
+// pozostawia datę zerową bez zgłaszania błędu
+type InvoiceBuilder struct {
+	invoice Invoice
+	errs    []error
+}
+
+// NewInvoiceBuilder tworzy pusty InvoiceBuilder
+func NewInvoiceBuilder() *InvoiceBuilder {
+	return &InvoiceBuilder{invoice: Invoice{Items: []InvoiceItem{}}}
+}
+
+// Type ustawia typ dokumentu (FZ, FS, KFZ, KFS, ...); wymagane pole
+func (b *InvoiceBuilder) Type(value string) *InvoiceBuilder {
+	if value == "" {
+		b.errs = append(b.errs, &BuilderError{"Type", "nie może być puste"})
+		return b
+	}
+	b.invoice.Type = value
+	return b
+}
+
+// Number ustawia numer faktury; wymagane pole
+func (b *InvoiceBuilder) Number(value string) *InvoiceBuilder {
+	if value == "" {
+		b.errs = append(b.errs, &BuilderError{"Number", "nie może być puste"})
+		return b
+	}
+	b.invoice.Number = value
+	return b
+}
+
+// NIP ustawia NIP kontrahenta; wymagane pole
+func (b *InvoiceBuilder) NIP(value string) *InvoiceBuilder {
+	if value == "" {
+		b.errs = append(b.errs, &BuilderError{"NIP", "nie może być puste"})
+		return b
+	}
+	b.invoice.NIP = value
+	return b
+}
+
+// ContractorName ustawia nazwę kontrahenta
+func (b *InvoiceBuilder) ContractorName(value string) *InvoiceBuilder {
+	b.invoice.ContractorName = value
+	return b
+}
+
+// ContractorCode ustawia kod kontrahenta
+func (b *InvoiceBuilder) ContractorCode(value string) *InvoiceBuilder {
+	b.invoice.ContractorCode = value
+	return b
+}
+
+// ContractorFullName ustawia pełną nazwę kontrahenta
+func (b *InvoiceBuilder) ContractorFullName(value string) *InvoiceBuilder {
+	b.invoice.ContractorFullName = value
+	return b
+}
+
+// Address ustawia adres, kod pocztowy i miasto kontrahenta
+func (b *InvoiceBuilder) Address(address, postalCode, city string) *InvoiceBuilder {
+	b.invoice.Address = address
+	b.invoice.PostalCode = postalCode
+	b.invoice.City = city
+	return b
+}
+
+// InternalNumber ustawia numer wewnętrzny dokumentu
+func (b *InvoiceBuilder) InternalNumber(value string) *InvoiceBuilder {
+	b.invoice.InternalNumber = value
+	return b
+}
+
+// Category ustawia kategorię dokumentu
+func (b *InvoiceBuilder) Category(value string) *InvoiceBuilder {
+	b.invoice.Category = value
+	return b
+}
+
+// Registrar ustawia rejestrator dokumentu
+func (b *InvoiceBuilder) Registrar(value string) *InvoiceBuilder {
+	b.invoice.Registrar = value
+	return b
+}
+
+// Date parsuje i ustawia datę dokumentu w formacie YYYYMMDDHHMMSS
+func (b *InvoiceBuilder) Date(value string) *InvoiceBuilder {
+	b.invoice.Date = ParseDate(value)
+	return b
+}
+
+// IssueDate parsuje i ustawia datę wystawienia w formacie YYYYMMDDHHMMSS; wymagane pole
+func (b *InvoiceBuilder) IssueDate(value string) *InvoiceBuilder {
+	date := ParseDate(value)
+	if date.IsZero() {
+		b.errs = append(b.errs, &BuilderError{"IssueDate", fmt.Sprintf("niepoprawny format daty: %q", value)})
+		return b
+	}
+	b.invoice.IssueDate = date
+	return b
+}
+
+// SaleDate parsuje i ustawia datę sprzedaży w formacie YYYYMMDDHHMMSS
+func (b *InvoiceBuilder) SaleDate(value string) *InvoiceBuilder {
+	b.invoice.SaleDate = ParseDate(value)
+	return b
+}
+
+// PaymentDate parsuje i ustawia termin płatności w formacie YYYYMMDDHHMMSS
+func (b *InvoiceBuilder) PaymentDate(value string) *InvoiceBuilder {
+	b.invoice.PaymentDate = ParseDate(value)
+	return b
+}
+
+// Amounts parsuje i ustawia kwoty netto, VAT i brutto. Ujemne kwoty brutto są
+// dopuszczalne (korekty KFZ/KFS obniżające wartość) - sprawdzenie, czy kwota
+// brutto jest dodatnia dla zwykłej faktury, należy do Validate
+func (b *InvoiceBuilder) Amounts(net, vat, gross string) *InvoiceBuilder {
+	netVal, err := strconv.ParseFloat(net, 64)
+	if err != nil {
+		b.errs = append(b.errs, &BuilderError{"NetAmount", fmt.Sprintf("niepoprawna kwota: %q", net)})
+	} else {
+		b.invoice.NetAmount = netVal
+	}
+
+	vatVal, err := strconv.ParseFloat(vat, 64)
+	if err != nil {
+		b.errs = append(b.errs, &BuilderError{"VatAmount", fmt.Sprintf("niepoprawna kwota: %q", vat)})
+	} else {
+		b.invoice.VatAmount = vatVal
+	}
+
+	grossVal, err := strconv.ParseFloat(gross, 64)
+	if err != nil {
+		b.errs = append(b.errs, &BuilderError{"GrossAmount", fmt.Sprintf("niepoprawna kwota: %q", gross)})
+	} else {
+		b.invoice.GrossAmount = grossVal
+	}
+
+	return b
+}
+
+// Currency ustawia walutę dokumentu
+func (b *InvoiceBuilder) Currency(value string) *InvoiceBuilder {
+	b.invoice.Currency = value
+	return b
+}
+
+// AddItem dołącza pozycję faktury zbudowaną przez InvoiceItemBuilder
+func (b *InvoiceBuilder) AddItem(item InvoiceItem) *InvoiceBuilder {
+	b.invoice.Items = append(b.invoice.Items, item)
+	return b
+}
+
+// Build zwraca zbudowaną fakturę oraz listę błędów napotkanych dla poszczególnych pól
+func (b *InvoiceBuilder) Build() (Invoice, []error) {
+	return b.invoice, b.errs
+}
+
+// InvoiceItemBuilder buduje InvoiceItem krok po kroku, zbierając BuilderError dla
+// każdego niepoprawnego pola
+type InvoiceItemBuilder struct {
+	item InvoiceItem
+	errs []error
+}
+
+// NewInvoiceItemBuilder tworzy pusty InvoiceItemBuilder
+func NewInvoiceItemBuilder() *InvoiceItemBuilder {
+	return &InvoiceItemBuilder{}
+}
+
+// VatRate ustawia stawkę VAT pozycji; wymagane pole
+func (b *InvoiceItemBuilder) VatRate(value string) *InvoiceItemBuilder {
+	if value == "" {
+		b.errs = append(b.errs, &BuilderError{"VatRate", "nie może być puste"})
+		return b
+	}
+	b.item.VatRate = value
+	return b
+}
+
+// Quantity parsuje i ustawia ilość
+func (b *InvoiceItemBuilder) Quantity(value string) *InvoiceItemBuilder {
+	val, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		b.errs = append(b.errs, &BuilderError{"Quantity", fmt.Sprintf("niepoprawna wartość: %q", value)})
+		return b
+	}
+	b.item.Quantity = val
+	return b
+}
+
+// NetValues parsuje i ustawia cenę oraz wartość netto pozycji
+func (b *InvoiceItemBuilder) NetValues(price, total string) *InvoiceItemBuilder {
+	priceVal, err := strconv.ParseFloat(price, 64)
+	if err != nil {
+		b.errs = append(b.errs, &BuilderError{"NetPrice", fmt.Sprintf("niepoprawna wartość: %q", price)})
+	} else {
+		b.item.NetPrice = priceVal
+	}
+
+	totalVal, err := strconv.ParseFloat(total, 64)
+	if err != nil {
+		b.errs = append(b.errs, &BuilderError{"NetTotal", fmt.Sprintf("niepoprawna wartość: %q", total)})
+	} else {
+		b.item.NetTotal = totalVal
+	}
+
+	return b
+}
+
+// GrossValues parsuje i ustawia cenę oraz wartość brutto pozycji
+func (b *InvoiceItemBuilder) GrossValues(price, total string) *InvoiceItemBuilder {
+	priceVal, err := strconv.ParseFloat(price, 64)
+	if err != nil {
+		b.errs = append(b.errs, &BuilderError{"GrossPrice", fmt.Sprintf("niepoprawna wartość: %q", price)})
+	} else {
+		b.item.GrossPrice = priceVal
+	}
+
+	totalVal, err := strconv.ParseFloat(total, 64)
+	if err != nil {
+		b.errs = append(b.errs, &BuilderError{"GrossTotal", fmt.Sprintf("niepoprawna wartość: %q", total)})
+	} else {
+		b.item.GrossTotal = totalVal
+	}
+
+	return b
+}
+
+// VatValues parsuje i ustawia kwotę oraz wartość VAT pozycji
+func (b *InvoiceItemBuilder) VatValues(amount, total string) *InvoiceItemBuilder {
+	amountVal, err := strconv.ParseFloat(amount, 64)
+	if err != nil {
+		b.errs = append(b.errs, &BuilderError{"VatAmount", fmt.Sprintf("niepoprawna wartość: %q", amount)})
+	} else {
+		b.item.VatAmount = amountVal
+	}
+
+	totalVal, err := strconv.ParseFloat(total, 64)
+	if err != nil {
+		b.errs = append(b.errs, &BuilderError{"VatTotal", fmt.Sprintf("niepoprawna wartość: %q", total)})
+	} else {
+		b.item.VatTotal = totalVal
+	}
+
+	return b
+}
+
+// Build zwraca zbudowaną pozycję faktury oraz listę błędów napotkanych dla
+// poszczególnych pól
+func (b *InvoiceItemBuilder) Build() (InvoiceItem, []error) {
+	return b.item, b.errs
+}
+
+// Validate sprawdza zbudowaną fakturę pod kątem reguł biznesowych, zwracając listę
+// wszystkich naruszeń - odpowiednik walidacji z przykładu validateData(), ale
+// dostępny jako część publicznego API biblioteki
+func Validate(invoice Invoice) []ValidationError {
+	var errs []ValidationError
+
+	if invoice.Number == "" {
+		errs = append(errs, ValidationError{"Number", fmt.Sprintf("brak numeru faktury (typ: %s)", invoice.Type)})
+	}
+
+	if invoice.NIP == "" {
+		errs = append(errs, ValidationError{"NIP", fmt.Sprintf("brak NIP kontrahenta w fakturze %s", invoice.Number)})
+	}
+
+	if invoice.IssueDate.IsZero() {
+		errs = append(errs, ValidationError{"IssueDate", fmt.Sprintf("brak daty wystawienia w fakturze %s", invoice.Number)})
+	}
+
+	switch invoice.Type {
+	case "KFZ", "KFS":
+		// Korekty mogą obniżać wartość faktury pierwotnej, więc kwota brutto może być ujemna -
+		// niedozwolone jest tylko zero
+		if invoice.GrossAmount == 0 {
+			errs = append(errs, ValidationError{"GrossAmount", fmt.Sprintf("kwota brutto nie może być zerowa w fakturze %s", invoice.Number)})
+		}
+	default:
+		if invoice.GrossAmount <= 0 {
+			errs = append(errs, ValidationError{"GrossAmount", fmt.Sprintf("kwota brutto musi być dodatnia w fakturze %s", invoice.Number)})
+		}
+	}
+
+	if invoice.ContractorName == "" {
+		errs = append(errs, ValidationError{"ContractorName", fmt.Sprintf("brak nazwy kontrahenta w fakturze %s", invoice.Number)})
+	}
+
+	return errs
+}
@@ -0,0 +1,44 @@
+package epp2json
+
+import "testing"
+
+// TestValidateKFZNegativeGrossAllowed sprawdza, że korekty KFZ/KFS mogą mieć
+// ujemną kwotę brutto (obniżenie wartości faktury pierwotnej), ale nie zerową
+func TestValidateKFZNegativeGrossAllowed(t *testing.T) {
+	invoice := Invoice{
+		Type:           "KFZ",
+		Number:         "KFZ/1/2024",
+		NIP:            "1234567890",
+		IssueDate:      ParseDate("20240115000000"),
+		ContractorName: "Kontrahent",
+		GrossAmount:    -123.62,
+	}
+
+	if errs := Validate(invoice); len(errs) != 0 {
+		t.Fatalf("Validate zwróciło błędy dla poprawnej korekty z ujemną kwotą: %v", errs)
+	}
+
+	invoice.GrossAmount = 0
+	errs := Validate(invoice)
+	if len(errs) != 1 || errs[0].Field != "GrossAmount" {
+		t.Fatalf("Validate powinno odrzucić zerową kwotę brutto w korekcie, otrzymano: %v", errs)
+	}
+}
+
+// TestValidateStandardInvoiceRejectsNonPositiveGross sprawdza, że zwykła faktura
+// (nie korekta) musi mieć dodatnią kwotę brutto
+func TestValidateStandardInvoiceRejectsNonPositiveGross(t *testing.T) {
+	invoice := Invoice{
+		Type:           "FS",
+		Number:         "FS/1/2024",
+		NIP:            "1234567890",
+		IssueDate:      ParseDate("20240115000000"),
+		ContractorName: "Kontrahent",
+		GrossAmount:    -1,
+	}
+
+	errs := Validate(invoice)
+	if len(errs) != 1 || errs[0].Field != "GrossAmount" {
+		t.Fatalf("Validate powinno odrzucić ujemną kwotę brutto w zwykłej fakturze, otrzymano: %v", errs)
+	}
+}
@@ -4,35 +4,50 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/janexpl/epp2json"
+	"github.com/janexpl/epp2json/render"
 )
 
 func main() {
-	var inputFile, outputFile string
+	var inputFile, outputFile, format, pdfOutDir string
 	var onlyFZ, onlyFS bool
 
 	flag.StringVar(&inputFile, "input", "eksport.epp", "Ścieżka do pliku wejściowego")
 	flag.StringVar(&outputFile, "output", "faktury.json", "Ścieżka do pliku wyjściowego")
+	flag.StringVar(&format, "format", "json", "Format wyjściowy: json, gobl lub ubl")
+	flag.StringVar(&pdfOutDir, "pdf-out", "", "Katalog, do którego zapisać PDF dla każdej faktury")
 	flag.BoolVar(&onlyFZ, "fz-only", false, "Parsuj tylko faktury zakupowe (FZ)")
 	flag.BoolVar(&onlyFS, "fs-only", false, "Parsuj tylko faktury sprzedażowe (FS)")
 	flag.Parse()
 
 	// Ustaw opcje parsowania
 	options := epp2json.DefaultParseOptions()
-	if onlyFZ {
-		options.IncludeFS = false
-	}
-	if onlyFS {
-		options.IncludeFZ = false
+	switch {
+	case onlyFZ:
+		options.Filter = epp2json.IncludeTypes("FZ", "KFZ")
+	case onlyFS:
+		options.Filter = epp2json.IncludeTypes("FS", "KFS")
 	}
 
-	// Konwertuj plik
-	jsonData, err := epp2json.ConvertEPPToJSON(inputFile, options)
+	// Konwertuj plik do wybranego formatu
+	var outputData []byte
+	var err error
+	switch format {
+	case "gobl":
+		outputData, err = epp2json.ConvertEPPToGoBL(inputFile, options)
+	case "ubl":
+		outputData, err = epp2json.ConvertEPPToUBL(inputFile, options)
+	default:
+		outputData, err = epp2json.ConvertEPPToJSON(inputFile, options)
+	}
 	if err != nil {
 		log.Fatal("Błąd konwersji:", err)
 	}
-	epp2json.WriteJSONToFile(jsonData, outputFile)
+	epp2json.WriteJSONToFile(outputData, outputFile)
 
 	// Pobierz statystyki
 	eppData, err := epp2json.ParseEPPFile(inputFile, options)
@@ -48,4 +63,36 @@ func main() {
 	fmt.Printf("Wynik zapisano do pliku: %s\n", outputFile)
 	fmt.Printf("Faktury zakupowe (FZ): %d\n", fzCount)
 	fmt.Printf("Faktury sprzedażowe (FS): %d\n", fsCount)
+
+	// Wyrenderuj PDF dla każdej faktury, jeśli podano katalog docelowy
+	if pdfOutDir != "" {
+		if err := renderInvoicesToPDF(eppData.Invoices, pdfOutDir); err != nil {
+			log.Fatal("Błąd podczas renderowania PDF:", err)
+		}
+		fmt.Printf("Zapisano %d plików PDF do katalogu: %s\n", totalCount, pdfOutDir)
+	}
+}
+
+// renderInvoicesToPDF renderuje każdą fakturę do osobnego pliku PDF w katalogu dir
+func renderInvoicesToPDF(invoices []epp2json.Invoice, dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("nie można utworzyć katalogu %s: %v", dir, err)
+	}
+
+	renderOptions := render.DefaultRenderOptions()
+	replacer := strings.NewReplacer("/", "_", "\\", "_", ":", "_")
+
+	for _, invoice := range invoices {
+		pdfData, err := render.RenderInvoicePDF(invoice, renderOptions)
+		if err != nil {
+			return fmt.Errorf("błąd podczas renderowania faktury %s: %v", invoice.Number, err)
+		}
+
+		filename := filepath.Join(dir, replacer.Replace(invoice.Number)+".pdf")
+		if err := os.WriteFile(filename, pdfData, 0644); err != nil {
+			return fmt.Errorf("błąd podczas zapisu pliku %s: %v", filename, err)
+		}
+	}
+
+	return nil
 }
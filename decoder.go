@@ -0,0 +1,162 @@
+package epp2json
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/transform"
+)
+
+const (
+	decoderInitialBufSize = 64 * 1024
+	decoderMaxBufSize     = 16 * 1024 * 1024
+)
+
+// Decoder czyta plik EPP sekcja po sekcji zamiast wczytywać całą zawartość do
+// pamięci, dzięki czemu nadaje się do przetwarzania wielusetmegabajtowych eksportów
+type Decoder struct {
+	scanner  *bufio.Scanner
+	options  ParseOptions
+	info     map[string]string
+	infoRead bool
+	warnings []string
+}
+
+// NewDecoder tworzy Decoder czytający z r, dekodując strumień Windows-1250 w locie
+func NewDecoder(r io.Reader, options ParseOptions) *Decoder {
+	decoded := transform.NewReader(r, charmap.Windows1250.NewDecoder())
+
+	scanner := bufio.NewScanner(decoded)
+	scanner.Buffer(make([]byte, 0, decoderInitialBufSize), decoderMaxBufSize)
+	scanner.Split(splitEPPBlocks)
+
+	return &Decoder{
+		scanner: scanner,
+		options: options,
+		info:    make(map[string]string),
+	}
+}
+
+// splitEPPBlocks to bufio.SplitFunc rozpoznający granice bloków [NAGLOWEK] w strumieniu
+// EPP; pierwszy zwrócony token to fragment poprzedzający pierwszy nagłówek (zawierający
+// [INFO]), kolejne to pojedyncze bloki [NAGLOWEK]...[ZAWARTOSC]...
+func splitEPPBlocks(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	const headerTag = "[NAGLOWEK]"
+
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+
+	// pomijamy dopasowanie na samym początku danych, żeby nie zwrócić pustego tokenu
+	if idx := bytes.Index(data[1:], []byte(headerTag)); idx >= 0 {
+		return idx + 1, data[:idx+1], nil
+	}
+
+	if atEOF {
+		return len(data), data, nil
+	}
+
+	// potrzebujemy więcej danych, żeby znaleźć kolejny [NAGLOWEK]
+	return 0, nil, nil
+}
+
+// parseInfo parsuje fragment poprzedzający pierwszy nagłówek faktury i wyciąga
+// sekcję [INFO]
+func (d *Decoder) parseInfo(block string) error {
+	idx := strings.Index(block, "[INFO]")
+	if idx < 0 {
+		return nil
+	}
+	info := strings.TrimSpace(block[idx+len("[INFO]"):])
+
+	fields, err := ParseCSVLine(info)
+	if err != nil {
+		return fmt.Errorf("błąd podczas parsowania info: %v", err)
+	}
+	if len(fields) >= 2 {
+		d.info["version"] = fields[0]
+		if len(fields) > 3 {
+			d.info["system"] = fields[3]
+		}
+		if len(fields) > 5 {
+			d.info["company"] = fields[5]
+		}
+	}
+	return nil
+}
+
+// Info zwraca metadane z sekcji [INFO]; dostępne dopiero po pierwszym wywołaniu Next
+func (d *Decoder) Info() map[string]string {
+	return d.info
+}
+
+// Warnings zwraca błędy pól napotkane przez ParseHeader/ParseItem przy dotychczas
+// zwróconych fakturach; w przeciwieństwie do błędu zwracanego z Next, nie przerywają
+// one dalszego czytania strumienia
+func (d *Decoder) Warnings() []string {
+	return d.warnings
+}
+
+// Next zwraca kolejną fakturę z pliku EPP, a po przetworzeniu wszystkich sekcji - io.EOF
+func (d *Decoder) Next() (Invoice, error) {
+	const contentTag = "[ZAWARTOSC]"
+
+	for d.scanner.Scan() {
+		block := d.scanner.Text()
+
+		if !d.infoRead {
+			d.infoRead = true
+			if err := d.parseInfo(block); err != nil {
+				return Invoice{}, err
+			}
+			continue
+		}
+
+		block = strings.TrimPrefix(block, "[NAGLOWEK]")
+		idx := strings.Index(block, contentTag)
+		if idx < 0 {
+			continue
+		}
+		header := strings.TrimSpace(block[:idx])
+		content := strings.TrimSpace(block[idx+len(contentTag):])
+
+		fields, err := ParseCSVLine(header)
+		if err != nil {
+			return Invoice{}, fmt.Errorf("błąd podczas parsowania nagłówka: %v", err)
+		}
+		if len(fields) == 0 {
+			continue
+		}
+
+		invoiceType := fields[0]
+		if !d.options.shouldInclude(invoiceType) {
+			continue
+		}
+
+		invoice, headerErrs := ParseHeader(fields)
+		invoice.Items = []InvoiceItem{}
+		d.warnings = appendFieldWarnings(d.warnings, invoiceType, headerErrs)
+
+		itemLines, err := ParseCSVLines(content)
+		if err != nil {
+			return Invoice{}, fmt.Errorf("błąd podczas parsowania pozycji: %v", err)
+		}
+		for _, itemFields := range itemLines {
+			item, itemErrs := ParseItem(itemFields)
+			invoice.Items = append(invoice.Items, item)
+			d.warnings = appendFieldWarnings(d.warnings, invoiceType, itemErrs)
+		}
+
+		return invoice, nil
+	}
+
+	if err := d.scanner.Err(); err != nil {
+		return Invoice{}, fmt.Errorf("błąd podczas czytania strumienia: %v", err)
+	}
+
+	return Invoice{}, io.EOF
+}
@@ -0,0 +1,78 @@
+package epp2json
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+const decoderFixture = "[INFO]\r\n" +
+	"\"1.0\",\"\",\"\",\"TestSystem\",\"\",\"Test Sp. z o.o.\"\r\n" +
+	"[NAGLOWEK]\r\n" +
+	"\"FZ\",\"\",\"\",\"\",\"FZ/1/2024\",\"\",\"WEW-1\",\"\",\"\",\"\",\"\"," +
+	"\"K001\",\"Kontrahent Sp. z o.o.\",\"Kontrahent Sp. z o.o. Pelna Nazwa\"," +
+	"\"Warszawa\",\"00-001\",\"ul. Testowa 1\",\"1234567890\",\"\",\"KAT001\",\"\"," +
+	"\"20240115000000\",\"20240115000000\",\"20240110000000\",\"\",\"\",\"\"," +
+	"\"100.5\",\"23.12\",\"123.62\"\r\n" +
+	"[ZAWARTOSC]\r\n" +
+	"\"23\",\"2\",\"50.25\",\"11.56\",\"61.81\",\"100.5\",\"23.12\",\"123.62\"\r\n" +
+	"\"8\",\"1\",\"10\",\"0.8\",\"10.8\",\"10\",\"0.8\",\"10.8\"\r\n" +
+	"[NAGLOWEK]\r\n" +
+	"\"FS\",\"\",\"\",\"\",\"FS/1/2024\",\"\",\"WEW-2\",\"\",\"\",\"\",\"\"," +
+	"\"K002\",\"Inny Kontrahent\",\"Inny Kontrahent Pelna Nazwa\"," +
+	"\"Krakow\",\"00-002\",\"ul. Inna 2\",\"0987654321\",\"\",\"KAT002\",\"\"," +
+	"\"20240201000000\",\"20240201000000\",\"20240125000000\",\"\",\"\",\"\"," +
+	"\"200\",\"46\",\"246\"\r\n" +
+	"[ZAWARTOSC]\r\n" +
+	"\"23\",\"1\",\"200\",\"46\",\"246\",\"200\",\"46\",\"246\"\r\n"
+
+// TestDecoderMatchesParseEPPFromString sprawdza, że Decoder.Next zwraca te same
+// faktury co wsadowy ParseEPPFromString dla tego samego pliku EPP
+func TestDecoderMatchesParseEPPFromString(t *testing.T) {
+	options := DefaultParseOptions()
+
+	expected, err := ParseEPPFromString(decoderFixture, options)
+	if err != nil {
+		t.Fatalf("nie udało się sparsować fixture: %v", err)
+	}
+
+	encoded, err := charmap.Windows1250.NewEncoder().String(decoderFixture)
+	if err != nil {
+		t.Fatalf("nie udało się zakodować fixture do Windows-1250: %v", err)
+	}
+
+	decoder := NewDecoder(strings.NewReader(encoded), options)
+
+	var got []Invoice
+	for {
+		invoice, err := decoder.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Decoder.Next zwróciło błąd: %v", err)
+		}
+		got = append(got, invoice)
+	}
+
+	if len(got) != len(expected.Invoices) {
+		t.Fatalf("Decoder zwrócił %d faktur, ParseEPPFromString %d", len(got), len(expected.Invoices))
+	}
+	for i := range expected.Invoices {
+		if got[i].Number != expected.Invoices[i].Number {
+			t.Errorf("faktura %d: Decoder.Number = %q, chcemy %q", i, got[i].Number, expected.Invoices[i].Number)
+		}
+		if got[i].GrossAmount != expected.Invoices[i].GrossAmount {
+			t.Errorf("faktura %d: Decoder.GrossAmount = %v, chcemy %v", i, got[i].GrossAmount, expected.Invoices[i].GrossAmount)
+		}
+		if len(got[i].Items) != len(expected.Invoices[i].Items) {
+			t.Errorf("faktura %d: Decoder zwrócił %d pozycji, chcemy %d", i, len(got[i].Items), len(expected.Invoices[i].Items))
+		}
+	}
+
+	if len(got) > 0 && len(got[0].Items) != 2 {
+		t.Fatalf("pierwsza faktura powinna mieć 2 pozycje pod jednym [ZAWARTOSC], otrzymano %d", len(got[0].Items))
+	}
+}
@@ -57,13 +57,49 @@ type InvoiceItem struct {
 type EPPData struct {
 	Info     map[string]string `json:"info"`
 	Invoices []Invoice         `json:"faktury"`
+	Warnings []string          `json:"ostrzezenia,omitempty"`
+}
+
+// TypeFilter decyduje, czy dokument o danym typie (FZ, FS, KFZ, KFS, ...) powinien
+// zostać uwzględniony w wyniku parsowania
+type TypeFilter func(invoiceType string) bool
+
+// IncludeTypes zwraca TypeFilter przepuszczający wyłącznie podane typy dokumentów
+func IncludeTypes(types ...string) TypeFilter {
+	allowed := make(map[string]bool, len(types))
+	for _, t := range types {
+		allowed[t] = true
+	}
+	return func(invoiceType string) bool {
+		return allowed[invoiceType]
+	}
+}
+
+// ExcludeTypes zwraca TypeFilter przepuszczający wszystkie typy dokumentów poza podanymi
+func ExcludeTypes(types ...string) TypeFilter {
+	excluded := make(map[string]bool, len(types))
+	for _, t := range types {
+		excluded[t] = true
+	}
+	return func(invoiceType string) bool {
+		return !excluded[invoiceType]
+	}
 }
 
 // ParseOptions zawiera opcje parsowania
 type ParseOptions struct {
-	IncludeFZ bool // Czy dołączać faktury zakupowe
-	IncludeFS bool // Czy dołączać faktury sprzedażowe
+	Filter TypeFilter // Określa, które typy dokumentów mają zostać uwzględnione
+}
+
+// shouldInclude sprawdza, czy dokument danego typu powinien zostać uwzględniony;
+// brak ustawionego filtra oznacza uwzględnienie wszystkich typów
+func (o ParseOptions) shouldInclude(invoiceType string) bool {
+	if o.Filter == nil {
+		return true
+	}
+	return o.Filter(invoiceType)
 }
+
 type Section struct {
 	Header  string
 	Content string
@@ -73,11 +109,11 @@ type EPPSections struct {
 	Sections []Section
 }
 
-// DefaultParseOptions zwraca domyślne opcje parsowania (wszystkie typy faktur)
+// DefaultParseOptions zwraca domyślne opcje parsowania: faktury zakupowe i
+// sprzedażowe wraz z ich korektami, paragony oraz dokumenty magazynowe WZ/PZ
 func DefaultParseOptions() ParseOptions {
 	return ParseOptions{
-		IncludeFZ: true,
-		IncludeFS: true,
+		Filter: IncludeTypes("FZ", "KFZ", "FS", "KFS", "PA", "WZ", "PZ"),
 	}
 }
 
@@ -99,6 +135,23 @@ func ParseFloat(str string) float64 {
 	}
 	return 0.0
 }
+
+// fieldAt zwraca pole o indeksie i, albo pusty string, jeśli fields jest za krótkie
+func fieldAt(fields []string, i int) string {
+	if i < len(fields) {
+		return fields[i]
+	}
+	return ""
+}
+
+// appendFieldWarnings dopisuje błędy zwrócone przez ParseHeader/ParseItem dla dokumentu
+// invoiceType do listy ostrzeżeń zamiast je po cichu pomijać
+func appendFieldWarnings(warnings []string, invoiceType string, errs []error) []string {
+	for _, err := range errs {
+		warnings = append(warnings, fmt.Sprintf("%s: %v", invoiceType, err))
+	}
+	return warnings
+}
 func ParseSections(input string) EPPSections {
 	const (
 		headerTag  = "[NAGLOWEK]"
@@ -153,104 +206,51 @@ func ParseCSVLine(line string) ([]string, error) {
 	return result, nil
 }
 
-// ParseHeader parsuje nagłówek faktury z pól CSV
-func ParseHeader(fields []string) Invoice {
-	invoice := Invoice{}
-
-	if len(fields) > 0 {
-		invoice.Type = fields[0]
-	}
-	if len(fields) > 4 {
-		invoice.Number = fields[4]
-	}
-	if len(fields) > 6 {
-		invoice.InternalNumber = fields[6]
-	}
-	if len(fields) > 11 {
-		invoice.ContractorCode = fields[11]
-	}
-	if len(fields) > 12 {
-		invoice.ContractorName = fields[12]
-	}
-	if len(fields) > 13 {
-		invoice.ContractorFullName = fields[13]
-	}
-	if len(fields) > 14 {
-		invoice.City = fields[14]
-	}
-	if len(fields) > 15 {
-		invoice.PostalCode = fields[15]
-	}
-	if len(fields) > 16 {
-		invoice.Address = fields[16]
-	}
-	if len(fields) > 17 {
-		invoice.NIP = fields[17]
-	}
-	if len(fields) > 18 {
-		invoice.Category = fields[19]
-	}
-	if len(fields) > 21 {
-		invoice.Date = ParseDate(fields[21])
-	}
-	if len(fields) > 22 {
-		invoice.IssueDate = ParseDate(fields[22])
-	}
-	if len(fields) > 23 {
-		invoice.SaleDate = ParseDate(fields[23])
-	}
-	if len(fields) > 27 {
-		invoice.NetAmount = ParseFloat(fields[27])
-	}
-	if len(fields) > 28 {
-		invoice.VatAmount = ParseFloat(fields[28])
-	}
-	if len(fields) > 29 {
-		invoice.GrossAmount = ParseFloat(fields[29])
-	}
-	if len(fields) > 34 {
-		invoice.PaymentDate = ParseDate(fields[34])
-	}
-	if len(fields) > 41 {
-		invoice.Registrar = fields[41]
-	}
-	if len(fields) > 46 {
-		invoice.Currency = fields[46]
+// ParseCSVLines parsuje zawartość wieloliniową jako kolejne rekordy CSV - używane
+// dla sekcji [ZAWARTOSC], która może zawierać więcej niż jedną pozycję faktury
+func ParseCSVLines(content string) ([][]string, error) {
+	reader := csv.NewReader(strings.NewReader(content))
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("błąd czytania CSV: %v", err)
 	}
 
-	return invoice
+	return records, nil
 }
 
-// ParseItem parsuje pozycję faktury z pól CSV
-func ParseItem(fields []string) InvoiceItem {
-	item := InvoiceItem{}
-
-	if len(fields) > 0 {
-		item.VatRate = fields[0]
-	}
-	if len(fields) > 1 {
-		item.Quantity = ParseFloat(fields[1])
-	}
-	if len(fields) > 2 {
-		item.NetPrice = ParseFloat(fields[2])
-	}
-	if len(fields) > 3 {
-		item.VatAmount = ParseFloat(fields[3])
-	}
-	if len(fields) > 4 {
-		item.GrossPrice = ParseFloat(fields[4])
-	}
-	if len(fields) > 5 {
-		item.NetTotal = ParseFloat(fields[5])
-	}
-	if len(fields) > 6 {
-		item.VatTotal = ParseFloat(fields[6])
-	}
-	if len(fields) > 7 {
-		item.GrossTotal = ParseFloat(fields[7])
-	}
+// ParseHeader parsuje nagłówek faktury z pól CSV za pomocą InvoiceBuilder, zwracając
+// błędy napotkane dla poszczególnych pól zamiast je po cichu pomijać
+func ParseHeader(fields []string) (Invoice, []error) {
+	return NewInvoiceBuilder().
+		Type(fieldAt(fields, 0)).
+		Number(fieldAt(fields, 4)).
+		InternalNumber(fieldAt(fields, 6)).
+		ContractorCode(fieldAt(fields, 11)).
+		ContractorName(fieldAt(fields, 12)).
+		ContractorFullName(fieldAt(fields, 13)).
+		Address(fieldAt(fields, 16), fieldAt(fields, 15), fieldAt(fields, 14)).
+		NIP(fieldAt(fields, 17)).
+		Category(fieldAt(fields, 19)).
+		Date(fieldAt(fields, 21)).
+		IssueDate(fieldAt(fields, 22)).
+		SaleDate(fieldAt(fields, 23)).
+		Amounts(fieldAt(fields, 27), fieldAt(fields, 28), fieldAt(fields, 29)).
+		PaymentDate(fieldAt(fields, 34)).
+		Registrar(fieldAt(fields, 41)).
+		Currency(fieldAt(fields, 46)).
+		Build()
+}
 
-	return item
+// ParseItem parsuje pozycję faktury z pól CSV za pomocą InvoiceItemBuilder, zwracając
+// błędy napotkane dla poszczególnych pól zamiast je po cichu pomijać
+func ParseItem(fields []string) (InvoiceItem, []error) {
+	return NewInvoiceItemBuilder().
+		VatRate(fieldAt(fields, 0)).
+		Quantity(fieldAt(fields, 1)).
+		NetValues(fieldAt(fields, 2), fieldAt(fields, 5)).
+		VatValues(fieldAt(fields, 3), fieldAt(fields, 6)).
+		GrossValues(fieldAt(fields, 4), fieldAt(fields, 7)).
+		Build()
 }
 
 // ParseEPPFromString parsuje zawartość pliku EPP z stringa
@@ -290,26 +290,30 @@ func ParseEPPFromString(content string, options ParseOptions) (*EPPData, error)
 		// Sprawdź czy to faktura FZ lub FS
 		if len(fields) > 0 {
 			invoiceType := fields[0]
-			shouldInclude := (invoiceType == "FZ" || invoiceType == "KFZ" && options.IncludeFZ) ||
-				(invoiceType == "FS" || invoiceType == "KFS" && options.IncludeFS)
 
-			if shouldInclude {
+			if options.shouldInclude(invoiceType) {
 				// Jeśli już mamy fakturę, dodaj ją do listy
 				if currentInvoice.Type != "" {
 					eppData.Invoices = append(eppData.Invoices, currentInvoice)
 				}
 
 				// Parsuj nowy nagłówek
-				currentInvoice = ParseHeader(fields)
+				var headerErrs []error
+				currentInvoice, headerErrs = ParseHeader(fields)
 				currentInvoice.Items = []InvoiceItem{}
-				fields, err = ParseCSVLine(section.Content)
+				eppData.Warnings = appendFieldWarnings(eppData.Warnings, invoiceType, headerErrs)
+				itemLines, err := ParseCSVLines(section.Content)
 				if err != nil {
 					return nil, fmt.Errorf("błąd podczas parsowania pozycji: %v", err)
 				}
-				// Parsuj pozycje faktury
+				// Parsuj wszystkie pozycje faktury - sekcja [ZAWARTOSC] może
+				// zawierać więcej niż jedną linię
 				if currentInvoice.Type != "" {
-					item := ParseItem(fields)
-					currentInvoice.Items = append(currentInvoice.Items, item)
+					for _, itemFields := range itemLines {
+						item, itemErrs := ParseItem(itemFields)
+						currentInvoice.Items = append(currentInvoice.Items, item)
+						eppData.Warnings = appendFieldWarnings(eppData.Warnings, invoiceType, itemErrs)
+					}
 				}
 			}
 
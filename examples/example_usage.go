@@ -16,8 +16,7 @@ func parseOnlyPurchaseInvoices() {
 	fmt.Println("=== Przykład 1: Tylko faktury zakupowe (FZ) ===")
 
 	options := epp2json.ParseOptions{
-		IncludeFZ: true,
-		IncludeFS: false,
+		Filter: epp2json.IncludeTypes("FZ", "KFZ"),
 	}
 
 	eppData, err := epp2json.ParseEPPFile("../eksport.epp", options)
@@ -138,20 +137,8 @@ func validateData() {
 	var invalidInvoices []string
 
 	for _, invoice := range eppData.Invoices {
-		// Sprawdź czy faktura ma prawidłowe dane
-		if invoice.Number == "" {
-			invalidInvoices = append(invalidInvoices,
-				fmt.Sprintf("Brak numeru faktury (typ: %s)", invoice.Type))
-		}
-
-		if invoice.GrossAmount < 0 {
-			invalidInvoices = append(invalidInvoices,
-				fmt.Sprintf("Ujemna kwota w fakturze %s", invoice.Number))
-		}
-
-		if invoice.ContractorName == "" {
-			invalidInvoices = append(invalidInvoices,
-				fmt.Sprintf("Brak nazwy kontrahenta w fakturze %s", invoice.Number))
+		for _, verr := range epp2json.Validate(invoice) {
+			invalidInvoices = append(invalidInvoices, verr.Error())
 		}
 	}
 
@@ -0,0 +1,162 @@
+package epp2json
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GoBLParty reprezentuje stronę transakcji (sprzedawcę lub nabywcę) w uproszczonej
+// kopercie GoBL (https://gobl.org)
+type GoBLParty struct {
+	Name       string `json:"name"`
+	TaxID      string `json:"tax_id,omitempty"`
+	Address    string `json:"address,omitempty"`
+	PostalCode string `json:"postal_code,omitempty"`
+	Locality   string `json:"locality,omitempty"`
+}
+
+// GoBLTax reprezentuje stawkę VAT przypisaną do pozycji faktury
+type GoBLTax struct {
+	Category string  `json:"cat"`
+	Rate     string  `json:"rate"`
+	Percent  float64 `json:"percent"`
+}
+
+// GoBLItem reprezentuje towar lub usługę w ramach pozycji faktury
+type GoBLItem struct {
+	Name  string  `json:"name"`
+	Price float64 `json:"price"`
+}
+
+// GoBLLine reprezentuje pojedynczą pozycję faktury w formacie GoBL
+type GoBLLine struct {
+	Quantity float64   `json:"quantity"`
+	Item     GoBLItem  `json:"item"`
+	Taxes    []GoBLTax `json:"taxes,omitempty"`
+	Total    float64   `json:"total"`
+}
+
+// GoBLTotals reprezentuje podsumowanie kwot faktury
+type GoBLTotals struct {
+	Sum   float64 `json:"sum"`
+	Tax   float64 `json:"tax"`
+	Total float64 `json:"total"`
+}
+
+// GoBLInvoice reprezentuje uproszczoną kopertę GoBL dla pojedynczej faktury lub
+// faktury korygującej (credit-note)
+type GoBLInvoice struct {
+	Schema    string     `json:"$schema"`
+	Type      string     `json:"type"`
+	Code      string     `json:"code"`
+	IssueDate string     `json:"issue_date,omitempty"`
+	SaleDate  string     `json:"sale_date,omitempty"`
+	DueDate   string     `json:"due_date,omitempty"`
+	Currency  string     `json:"currency,omitempty"`
+	Supplier  GoBLParty  `json:"supplier"`
+	Customer  GoBLParty  `json:"customer"`
+	Lines     []GoBLLine `json:"lines"`
+	Totals    GoBLTotals `json:"totals"`
+}
+
+// formatGoBLDate formatuje datę do postaci YYYY-MM-DD wymaganej przez GoBL,
+// zwracając pusty string dla daty zerowej
+func formatGoBLDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+// parseVatPercent wyciąga wartość procentową ze stawki VAT zapisanej jako string
+// (np. "23", "23%", "zw")
+func parseVatPercent(rate string) float64 {
+	trimmed := strings.TrimSuffix(strings.TrimSpace(rate), "%")
+	if val, err := strconv.ParseFloat(trimmed, 64); err == nil {
+		return val
+	}
+	return 0.0
+}
+
+// MapInvoiceToGoBL mapuje pojedynczą fakturę EPP na uproszczoną kopertę GoBL.
+// FZ/FS stają się dokumentem "standard", KFZ/KFS dokumentem "credit-note";
+// dla FS stroną sprzedającą jest nasza firma, dla FZ - kontrahent.
+func MapInvoiceToGoBL(invoice Invoice, company string) GoBLInvoice {
+	contractor := GoBLParty{
+		Name:       invoice.ContractorName,
+		TaxID:      invoice.NIP,
+		Address:    invoice.Address,
+		PostalCode: invoice.PostalCode,
+		Locality:   invoice.City,
+	}
+	us := GoBLParty{Name: company}
+
+	var supplier, customer GoBLParty
+	switch invoice.Type {
+	case "FZ", "KFZ":
+		supplier, customer = contractor, us
+	default:
+		supplier, customer = us, contractor
+	}
+
+	docType := "standard"
+	if invoice.Type == "KFZ" || invoice.Type == "KFS" {
+		docType = "credit-note"
+	}
+
+	lines := make([]GoBLLine, 0, len(invoice.Items))
+	for _, item := range invoice.Items {
+		lines = append(lines, GoBLLine{
+			Quantity: item.Quantity,
+			Item: GoBLItem{
+				Name:  fmt.Sprintf("Pozycja VAT %s", item.VatRate),
+				Price: item.NetPrice,
+			},
+			Taxes: []GoBLTax{
+				{Category: "VAT", Rate: item.VatRate, Percent: parseVatPercent(item.VatRate)},
+			},
+			Total: item.NetTotal,
+		})
+	}
+
+	return GoBLInvoice{
+		Schema:    "bill/invoice",
+		Type:      docType,
+		Code:      invoice.Number,
+		IssueDate: formatGoBLDate(invoice.IssueDate),
+		SaleDate:  formatGoBLDate(invoice.SaleDate),
+		DueDate:   formatGoBLDate(invoice.PaymentDate),
+		Currency:  invoice.Currency,
+		Supplier:  supplier,
+		Customer:  customer,
+		Lines:     lines,
+		Totals: GoBLTotals{
+			Sum:   invoice.NetAmount,
+			Tax:   invoice.VatAmount,
+			Total: invoice.GrossAmount,
+		},
+	}
+}
+
+// ConvertEPPToGoBL konwertuje plik EPP na listę kopert GoBL zapisanych jako JSON
+func ConvertEPPToGoBL(inputFile string, options ParseOptions) (jsonData []byte, err error) {
+	eppData, err := ParseEPPFile(inputFile, options)
+	if err != nil {
+		return nil, fmt.Errorf("błąd podczas parsowania pliku EPP: %v", err)
+	}
+
+	invoices := make([]GoBLInvoice, 0, len(eppData.Invoices))
+	for _, invoice := range eppData.Invoices {
+		invoices = append(invoices, MapInvoiceToGoBL(invoice, eppData.Info["company"]))
+	}
+
+	jsonData, err = json.MarshalIndent(invoices, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("błąd podczas konwersji do GoBL: %v", err)
+	}
+
+	return jsonData, nil
+}
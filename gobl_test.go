@@ -0,0 +1,38 @@
+package epp2json
+
+import "testing"
+
+// TestMapInvoiceToGoBLPartiesAndVat sprawdza kierunek stron sprzedawca/nabywca
+// w zależności od typu faktury oraz parsowanie stawek VAT (w tym zwolnionych, "zw")
+func TestMapInvoiceToGoBLPartiesAndVat(t *testing.T) {
+	invoice := Invoice{
+		Type:           "FZ",
+		ContractorName: "Kontrahent",
+		Items: []InvoiceItem{
+			{VatRate: "23"},
+			{VatRate: "zw"},
+		},
+	}
+
+	doc := MapInvoiceToGoBL(invoice, "Nasza Firma")
+
+	if doc.Supplier.Name != "Kontrahent" || doc.Customer.Name != "Nasza Firma" {
+		t.Fatalf("dla FZ dostawcą powinien być kontrahent, nabywcą my; otrzymano supplier=%q customer=%q", doc.Supplier.Name, doc.Customer.Name)
+	}
+	if doc.Type != "standard" {
+		t.Errorf("FZ powinno mapować się na typ standard, otrzymano %q", doc.Type)
+	}
+	if doc.Lines[0].Taxes[0].Percent != 23 {
+		t.Errorf("stawka 23 powinna dać Percent=23, otrzymano %v", doc.Lines[0].Taxes[0].Percent)
+	}
+	if doc.Lines[1].Taxes[0].Percent != 0 {
+		t.Errorf("stawka zw powinna dać Percent=0, otrzymano %v", doc.Lines[1].Taxes[0].Percent)
+	}
+
+	credit := invoice
+	credit.Type = "KFZ"
+	creditDoc := MapInvoiceToGoBL(credit, "Nasza Firma")
+	if creditDoc.Type != "credit-note" {
+		t.Errorf("KFZ powinno mapować się na typ credit-note, otrzymano %q", creditDoc.Type)
+	}
+}
@@ -0,0 +1,217 @@
+// Package jpk mapuje dane EPP na struktury XML wymagane w polskich rozliczeniach
+// podatkowych: Jednolity Plik Kontrolny (JPK_V7M/V7K) oraz e-faktury KSeF FA(2)
+package jpk
+
+import (
+	"encoding/xml"
+	"fmt"
+	"time"
+
+	"github.com/janexpl/epp2json"
+)
+
+// TaxpayerInfo zawiera dane podatnika składającego JPK lub wystawiającego e-fakturę
+type TaxpayerInfo struct {
+	NIP   string
+	Name  string
+	Email string
+	Phone string
+}
+
+type jpkHeader struct {
+	KodFormularza      string `xml:"KodFormularza"`
+	WariantFormularza  string `xml:"WariantFormularza"`
+	CelZlozenia        string `xml:"CelZlozenia"`
+	DataWytworzeniaJPK string `xml:"DataWytworzeniaJPK"`
+	Rok                string `xml:"Rok"`
+	Miesiac            string `xml:"Miesiac"`
+}
+
+type jpkSubject struct {
+	NIP     string `xml:"NIP"`
+	Nazwa   string `xml:"PelnaNazwa"`
+	Email   string `xml:"Email,omitempty"`
+	Telefon string `xml:"Telefon,omitempty"`
+}
+
+// SprzedazWiersz reprezentuje jeden wiersz ewidencji sprzedaży JPK_V7 (FS/KFS)
+type SprzedazWiersz struct {
+	LpSprzedazy      int    `xml:"LpSprzedazy"`
+	NrKontrahenta    string `xml:"NrKontrahenta,omitempty"`
+	NazwaKontrahenta string `xml:"NazwaKontrahenta"`
+	DowodSprzedazy   string `xml:"DowodSprzedazy"`
+	DataWystawienia  string `xml:"DataWystawienia"`
+	DataSprzedazy    string `xml:"DataSprzedazy,omitempty"`
+	K_10             string `xml:"K_10,omitempty"`
+	K_13             string `xml:"K_13,omitempty"`
+	K_14             string `xml:"K_14,omitempty"`
+	K_15             string `xml:"K_15,omitempty"`
+	K_16             string `xml:"K_16,omitempty"`
+	K_17             string `xml:"K_17,omitempty"`
+	K_18             string `xml:"K_18,omitempty"`
+	K_19             string `xml:"K_19,omitempty"`
+	K_20             string `xml:"K_20,omitempty"`
+}
+
+// ZakupWiersz reprezentuje jeden wiersz ewidencji zakupu JPK_V7 (FZ/KFZ)
+type ZakupWiersz struct {
+	LpZakupu      int    `xml:"LpZakupu"`
+	NrDostawcy    string `xml:"NrDostawcy,omitempty"`
+	NazwaDostawcy string `xml:"NazwaDostawcy"`
+	DowodZakupu   string `xml:"DowodZakupu"`
+	DataZakupu    string `xml:"DataZakupu,omitempty"`
+	DataWplywu    string `xml:"DataWplywu,omitempty"`
+	K_42          string `xml:"K_42,omitempty"`
+	K_43          string `xml:"K_43,omitempty"`
+}
+
+type sprzedazCtrl struct {
+	LiczbaWierszySprzedazy int    `xml:"LiczbaWierszySprzedazy"`
+	PodatekNalezny         string `xml:"PodatekNalezny"`
+}
+
+type zakupCtrl struct {
+	LiczbaWierszyZakupow int    `xml:"LiczbaWierszyZakupow"`
+	PodatekNaliczony     string `xml:"PodatekNaliczony"`
+}
+
+// jpkV7 to uproszczona reprezentacja deklaracji JPK_V7M/V7K
+type jpkV7 struct {
+	XMLName      xml.Name         `xml:"JPK"`
+	Naglowek     jpkHeader        `xml:"Naglowek"`
+	Podmiot1     jpkSubject       `xml:"Podmiot1"`
+	Sprzedaz     []SprzedazWiersz `xml:"SprzedazWiersz"`
+	SprzedazCtrl sprzedazCtrl     `xml:"SprzedazCtrl"`
+	Zakup        []ZakupWiersz    `xml:"ZakupWiersz"`
+	ZakupCtrl    zakupCtrl        `xml:"ZakupCtrl"`
+}
+
+// netVatFields dobiera parę pól kontrolnych K_ dla sprzedaży na podstawie stawki VAT;
+// uproszczenie pełnej struktury JPK_V7, która rozróżnia znacznie więcej przypadków
+func netVatFields(vatRate string) (netField, vatField string) {
+	switch vatRate {
+	case "23":
+		return "K_19", "K_20"
+	case "8":
+		return "K_17", "K_18"
+	case "5":
+		return "K_15", "K_16"
+	case "0":
+		return "K_13", "K_14"
+	default:
+		return "K_10", ""
+	}
+}
+
+// formatAmount formatuje kwotę tak, jak wymaga JPK - z dwoma miejscami po przecinku
+func formatAmount(v float64) string {
+	return fmt.Sprintf("%.2f", v)
+}
+
+// formatJPKDate formatuje datę do postaci YYYY-MM-DD wymaganej przez JPK/KSeF,
+// zwracając pusty string dla daty zerowej, tak by tagi omitempty zadziałały
+func formatJPKDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+// ExportJPK_V7 mapuje dane EPP za okres period na deklarację JPK_V7M/V7K: faktury
+// FS/KFS trafiają do wierszy ewidencji sprzedaży, a FZ/KFZ do wierszy ewidencji zakupu
+func ExportJPK_V7(data *epp2json.EPPData, period time.Time, taxpayer TaxpayerInfo) ([]byte, error) {
+	doc := jpkV7{
+		Naglowek: jpkHeader{
+			KodFormularza:      "JPK_VAT",
+			WariantFormularza:  "2",
+			CelZlozenia:        "1",
+			DataWytworzeniaJPK: period.Format(time.RFC3339),
+			Rok:                period.Format("2006"),
+			Miesiac:            period.Format("1"),
+		},
+		Podmiot1: jpkSubject{
+			NIP:     taxpayer.NIP,
+			Nazwa:   taxpayer.Name,
+			Email:   taxpayer.Email,
+			Telefon: taxpayer.Phone,
+		},
+	}
+
+	var salesVat, purchaseVat float64
+
+	for _, invoice := range data.Invoices {
+		switch invoice.Type {
+		case "FS", "KFS":
+			for _, item := range invoice.Items {
+				netField, vatField := netVatFields(item.VatRate)
+				row := SprzedazWiersz{
+					LpSprzedazy:      len(doc.Sprzedaz) + 1,
+					NrKontrahenta:    invoice.NIP,
+					NazwaKontrahenta: invoice.ContractorName,
+					DowodSprzedazy:   invoice.Number,
+					DataWystawienia:  formatJPKDate(invoice.IssueDate),
+					DataSprzedazy:    formatJPKDate(invoice.SaleDate),
+				}
+				setJPKField(&row, netField, formatAmount(item.NetTotal))
+				if vatField != "" {
+					setJPKField(&row, vatField, formatAmount(item.VatTotal))
+				}
+				doc.Sprzedaz = append(doc.Sprzedaz, row)
+				salesVat += item.VatTotal
+			}
+		case "FZ", "KFZ":
+			row := ZakupWiersz{
+				LpZakupu:      len(doc.Zakup) + 1,
+				NrDostawcy:    invoice.NIP,
+				NazwaDostawcy: invoice.ContractorName,
+				DowodZakupu:   invoice.Number,
+				DataZakupu:    formatJPKDate(invoice.SaleDate),
+				DataWplywu:    formatJPKDate(invoice.Date),
+				K_42:          formatAmount(invoice.NetAmount),
+				K_43:          formatAmount(invoice.VatAmount),
+			}
+			doc.Zakup = append(doc.Zakup, row)
+			purchaseVat += invoice.VatAmount
+		}
+	}
+
+	doc.SprzedazCtrl = sprzedazCtrl{
+		LiczbaWierszySprzedazy: len(doc.Sprzedaz),
+		PodatekNalezny:         formatAmount(salesVat),
+	}
+	doc.ZakupCtrl = zakupCtrl{
+		LiczbaWierszyZakupow: len(doc.Zakup),
+		PodatekNaliczony:     formatAmount(purchaseVat),
+	}
+
+	xmlData, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("błąd podczas konwersji do JPK_V7: %v", err)
+	}
+
+	return append([]byte(xml.Header), xmlData...), nil
+}
+
+// setJPKField ustawia wartość pola K_xx w wierszu sprzedaży na podstawie jego nazwy
+func setJPKField(row *SprzedazWiersz, field, value string) {
+	switch field {
+	case "K_10":
+		row.K_10 = value
+	case "K_13":
+		row.K_13 = value
+	case "K_14":
+		row.K_14 = value
+	case "K_15":
+		row.K_15 = value
+	case "K_16":
+		row.K_16 = value
+	case "K_17":
+		row.K_17 = value
+	case "K_18":
+		row.K_18 = value
+	case "K_19":
+		row.K_19 = value
+	case "K_20":
+		row.K_20 = value
+	}
+}
@@ -0,0 +1,108 @@
+package jpk
+
+import (
+	"encoding/xml"
+	"fmt"
+
+	"github.com/janexpl/epp2json"
+)
+
+type ksefIdentity struct {
+	NIP   string `xml:"NIP"`
+	Nazwa string `xml:"Nazwa"`
+}
+
+type ksefParty struct {
+	DaneIdentyfikacyjne ksefIdentity `xml:"DaneIdentyfikacyjne"`
+}
+
+type ksefHeader struct {
+	KodFormularza     string `xml:"KodFormularza"`
+	WariantFormularza string `xml:"WariantFormularza"`
+	DataWytworzeniaFa string `xml:"DataWytworzeniaFa"`
+	SystemInfo        string `xml:"SystemInfo"`
+}
+
+// FaWiersz reprezentuje pozycję faktury FA(2)
+type FaWiersz struct {
+	NrWierszaFa int    `xml:"NrWierszaFa"`
+	P_7         string `xml:"P_7"`  // nazwa towaru lub usługi
+	P_8B        string `xml:"P_8B"` // ilość
+	P_9A        string `xml:"P_9A"` // cena jednostkowa netto
+	P_11        string `xml:"P_11"` // wartość sprzedaży netto
+	P_12        string `xml:"P_12"` // stawka VAT
+}
+
+type ksefFa struct {
+	KodWaluty string     `xml:"KodWaluty"`
+	P_1       string     `xml:"P_1"` // data wystawienia
+	P_2       string     `xml:"P_2"` // numer faktury
+	P_6       string     `xml:"P_6,omitempty"` // data dokonania/zakończenia dostawy
+	P_13_1    string     `xml:"P_13_1"` // suma wartości netto
+	P_14_1    string     `xml:"P_14_1"` // suma kwoty VAT
+	P_15      string     `xml:"P_15"` // kwota należności ogółem
+	Wiersze   []FaWiersz `xml:"FaWiersz"`
+}
+
+// KSeFInvoice to uproszczona reprezentacja dokumentu FA(2) używanego w Krajowym
+// Systemie e-Faktur
+type KSeFInvoice struct {
+	XMLName  xml.Name   `xml:"Faktura"`
+	Naglowek ksefHeader `xml:"Naglowek"`
+	Podmiot1 ksefParty  `xml:"Podmiot1"`
+	Podmiot2 ksefParty  `xml:"Podmiot2"`
+	Fa       ksefFa     `xml:"Fa"`
+}
+
+// ExportKSeFFA2 mapuje pojedynczą fakturę EPP na dokument FA(2) Krajowego Systemu
+// e-Faktur. Dla FS/KFS podmiotem wystawiającym (Podmiot1) jest nasza firma, dla
+// FZ/KFZ - kontrahent.
+func ExportKSeFFA2(invoice epp2json.Invoice, taxpayer TaxpayerInfo) ([]byte, error) {
+	us := ksefParty{DaneIdentyfikacyjne: ksefIdentity{NIP: taxpayer.NIP, Nazwa: taxpayer.Name}}
+	contractor := ksefParty{DaneIdentyfikacyjne: ksefIdentity{NIP: invoice.NIP, Nazwa: invoice.ContractorName}}
+
+	seller, buyer := us, contractor
+	if invoice.Type == "FZ" || invoice.Type == "KFZ" {
+		seller, buyer = contractor, us
+	}
+
+	wiersze := make([]FaWiersz, 0, len(invoice.Items))
+	for i, item := range invoice.Items {
+		wiersze = append(wiersze, FaWiersz{
+			NrWierszaFa: i + 1,
+			P_7:         fmt.Sprintf("Pozycja VAT %s", item.VatRate),
+			P_8B:        formatAmount(item.Quantity),
+			P_9A:        formatAmount(item.NetPrice),
+			P_11:        formatAmount(item.NetTotal),
+			P_12:        item.VatRate,
+		})
+	}
+
+	doc := KSeFInvoice{
+		Naglowek: ksefHeader{
+			KodFormularza:     "FA",
+			WariantFormularza: "2",
+			DataWytworzeniaFa: invoice.IssueDate.Format("2006-01-02T15:04:05Z"),
+			SystemInfo:        "epp2json",
+		},
+		Podmiot1: seller,
+		Podmiot2: buyer,
+		Fa: ksefFa{
+			KodWaluty: invoice.Currency,
+			P_1:       invoice.IssueDate.Format("2006-01-02"),
+			P_2:       invoice.Number,
+			P_6:       formatJPKDate(invoice.SaleDate),
+			P_13_1:    formatAmount(invoice.NetAmount),
+			P_14_1:    formatAmount(invoice.VatAmount),
+			P_15:      formatAmount(invoice.GrossAmount),
+			Wiersze:   wiersze,
+		},
+	}
+
+	xmlData, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("błąd podczas konwersji do KSeF FA(2): %v", err)
+	}
+
+	return append([]byte(xml.Header), xmlData...), nil
+}
@@ -0,0 +1,32 @@
+package jpk
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/janexpl/epp2json"
+)
+
+// TestExportKSeFFA2ZeroSaleDate sprawdza, że brak daty sprzedaży (typowe dla
+// dokumentów WZ/PZ/PA) nie trafia do P_6 jako "0001-01-01", tylko jest pomijane
+// zgodnie z omitempty
+func TestExportKSeFFA2ZeroSaleDate(t *testing.T) {
+	invoice := epp2json.Invoice{
+		Type:      "FS",
+		Number:    "WZ/1/2024",
+		IssueDate: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+	}
+
+	xmlData, err := ExportKSeFFA2(invoice, TaxpayerInfo{NIP: "1234567890", Name: "Nasza Firma"})
+	if err != nil {
+		t.Fatalf("ExportKSeFFA2 zwróciło błąd: %v", err)
+	}
+
+	if strings.Contains(string(xmlData), "0001-01-01") {
+		t.Fatalf("dokument FA(2) zawiera zerową datę 0001-01-01:\n%s", xmlData)
+	}
+	if strings.Contains(string(xmlData), "<P_6>") {
+		t.Fatalf("P_6 powinno być pominięte dla zerowej daty sprzedaży:\n%s", xmlData)
+	}
+}
@@ -0,0 +1,131 @@
+package render
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+var wordUnits = []string{"", "jeden", "dwa", "trzy", "cztery", "pięć", "sześć", "siedem", "osiem", "dziewięć"}
+var wordTeens = []string{"dziesięć", "jedenaście", "dwanaście", "trzynaście", "czternaście", "piętnaście", "szesnaście", "siedemnaście", "osiemnaście", "dziewiętnaście"}
+var wordTens = []string{"", "", "dwadzieścia", "trzydzieści", "czterdzieści", "pięćdziesiąt", "sześćdziesiąt", "siedemdziesiąt", "osiemdziesiąt", "dziewięćdziesiąt"}
+var wordHundreds = []string{"", "sto", "dwieście", "trzysta", "czterysta", "pięćset", "sześćset", "siedemset", "osiemset", "dziewięćset"}
+
+// scaleForms zawiera formy gramatyczne nazwy rzędu wielkości (1, 2-4, 5+),
+// np. dla tysięcy: tysiąc/tysiące/tysięcy
+type scaleForms struct {
+	one, few, many string
+}
+
+var wordScales = []scaleForms{
+	{}, // jednostki - bez nazwy rzędu
+	{"tysiąc", "tysiące", "tysięcy"},
+	{"milion", "miliony", "milionów"},
+	{"miliard", "miliardy", "miliardów"},
+}
+
+// scaleForm dobiera formę gramatyczną rzędu wielkości odpowiednią dla liczby n
+func scaleForm(n int, forms scaleForms) string {
+	if n == 1 {
+		return forms.one
+	}
+	lastDigit := n % 10
+	lastTwoDigits := n % 100
+	if lastDigit >= 2 && lastDigit <= 4 && !(lastTwoDigits >= 12 && lastTwoDigits <= 14) {
+		return forms.few
+	}
+	return forms.many
+}
+
+// threeDigitsToWords zamienia liczbę z przedziału 0-999 na polski zapis słowny
+func threeDigitsToWords(n int) string {
+	var parts []string
+
+	hundredsDigit := n / 100
+	rest := n % 100
+
+	if hundredsDigit > 0 {
+		parts = append(parts, wordHundreds[hundredsDigit])
+	}
+
+	switch {
+	case rest >= 10 && rest < 20:
+		parts = append(parts, wordTeens[rest-10])
+	default:
+		tensDigit := rest / 10
+		unitsDigit := rest % 10
+		if tensDigit > 0 {
+			parts = append(parts, wordTens[tensDigit])
+		}
+		if unitsDigit > 0 {
+			parts = append(parts, wordUnits[unitsDigit])
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// numberToWords zamienia nieujemną liczbę całkowitą na jej polski zapis słowny.
+// Liczby większe niż największy obsługiwany rząd wielkości (miliardy) są
+// zwracane w postaci cyfrowej zamiast wywoływać panikę na wordScales
+func numberToWords(n int) string {
+	if n == 0 {
+		return "zero"
+	}
+
+	orig := n
+	var groups []int
+	for n > 0 {
+		groups = append(groups, n%1000)
+		n /= 1000
+	}
+
+	if len(groups) > len(wordScales) {
+		return fmt.Sprintf("%d", orig)
+	}
+
+	var parts []string
+	for i := len(groups) - 1; i >= 0; i-- {
+		group := groups[i]
+		if group == 0 {
+			continue
+		}
+
+		if i == 0 {
+			parts = append(parts, threeDigitsToWords(group))
+			continue
+		}
+
+		scale := wordScales[i]
+		if group == 1 {
+			parts = append(parts, scale.one)
+		} else {
+			parts = append(parts, threeDigitsToWords(group), scaleForm(group, scale))
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// amountInWordsPLN zapisuje kwotę słownie w formie używanej na fakturach,
+// np. "sto dwadzieścia trzy złote 62/100". Kwoty ujemne (korekty KFZ/KFS)
+// otrzymują przedrostek "minus"
+func amountInWordsPLN(amount float64) string {
+	negative := amount < 0
+	if negative {
+		amount = -amount
+	}
+
+	zloty := int(math.Floor(amount))
+	grosze := int(math.Round((amount - math.Floor(amount)) * 100))
+	if grosze == 100 {
+		zloty++
+		grosze = 0
+	}
+
+	words := fmt.Sprintf("%s %s %02d/100", numberToWords(zloty), scaleForm(zloty, scaleForms{"złoty", "złote", "złotych"}), grosze)
+	if negative {
+		return "minus " + words
+	}
+	return words
+}
@@ -0,0 +1,22 @@
+package render
+
+import "testing"
+
+// TestAmountInWordsPLNNegative sprawdza, że kwoty ujemne (korekty KFZ/KFS)
+// otrzymują przedrostek "minus" zamiast pustego zapisu słownego
+func TestAmountInWordsPLNNegative(t *testing.T) {
+	got := amountInWordsPLN(-123.62)
+	want := "minus sto dwadzieścia trzy złote 62/100"
+	if got != want {
+		t.Fatalf("amountInWordsPLN(-123.62) = %q, chcemy %q", got, want)
+	}
+}
+
+// TestAmountInWordsPLNOverflow sprawdza, że kwoty przekraczające największy
+// obsługiwany rząd wielkości (miliardy) nie powodują paniki na wordScales
+func TestAmountInWordsPLNOverflow(t *testing.T) {
+	got := amountInWordsPLN(1_000_000_000_000.50)
+	if got == "" {
+		t.Fatal("amountInWordsPLN zwróciło pusty string dla kwoty przekraczającej miliardy")
+	}
+}
@@ -0,0 +1,111 @@
+// Package render renderuje faktury EPP do HTML, a następnie do PDF za pomocą
+// zewnętrznej binarki wkhtmltopdf - analogicznie do pipeline'u html/template →
+// wkhtmltopdf używanego w usłudze fakturowania hscloud
+package render
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"os/exec"
+	"time"
+
+	"github.com/janexpl/epp2json"
+)
+
+//go:embed templates/*.html
+var templatesFS embed.FS
+
+// RenderOptions konfiguruje renderowanie faktury
+type RenderOptions struct {
+	Language        string // "pl" lub "en"; domyślnie "pl"
+	WkhtmltopdfPath string // ścieżka do binarki wkhtmltopdf; domyślnie "wkhtmltopdf"
+}
+
+// DefaultRenderOptions zwraca domyślne opcje renderowania (szablon polski,
+// wkhtmltopdf dostępne w PATH)
+func DefaultRenderOptions() RenderOptions {
+	return RenderOptions{
+		Language:        "pl",
+		WkhtmltopdfPath: "wkhtmltopdf",
+	}
+}
+
+// templateData to dane przekazywane do szablonu HTML faktury
+type templateData struct {
+	Invoice       epp2json.Invoice
+	AmountInWords string
+	IssueDate     string
+	SaleDate      string
+	PaymentDate   string
+}
+
+// formatInvoiceDate formatuje datę do postaci YYYY-MM-DD, zwracając pusty
+// string dla daty zerowej, tak by brakująca data nie renderowała się jako
+// "0001-01-01" na fakturze
+func formatInvoiceDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+// templateName zwraca nazwę osadzonego szablonu dla wybranego języka
+func templateName(opts RenderOptions) string {
+	if opts.Language == "en" {
+		return "invoice_en.html"
+	}
+	return "invoice_pl.html"
+}
+
+// RenderInvoiceHTML renderuje fakturę do HTML na podstawie osadzonego szablonu
+func RenderInvoiceHTML(inv epp2json.Invoice, opts RenderOptions) ([]byte, error) {
+	tmplFile := templateName(opts)
+
+	tmpl, err := template.ParseFS(templatesFS, "templates/"+tmplFile)
+	if err != nil {
+		return nil, fmt.Errorf("błąd podczas wczytywania szablonu %s: %v", tmplFile, err)
+	}
+
+	data := templateData{
+		Invoice:       inv,
+		AmountInWords: amountInWordsPLN(inv.GrossAmount),
+		IssueDate:     formatInvoiceDate(inv.IssueDate),
+		SaleDate:      formatInvoiceDate(inv.SaleDate),
+		PaymentDate:   formatInvoiceDate(inv.PaymentDate),
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("błąd podczas renderowania szablonu %s: %v", tmplFile, err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// RenderInvoicePDF renderuje fakturę do PDF, przepuszczając wygenerowany HTML przez wkhtmltopdf
+func RenderInvoicePDF(inv epp2json.Invoice, opts RenderOptions) ([]byte, error) {
+	html, err := RenderInvoiceHTML(inv, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	wkhtmltopdfPath := opts.WkhtmltopdfPath
+	if wkhtmltopdfPath == "" {
+		wkhtmltopdfPath = "wkhtmltopdf"
+	}
+
+	cmd := exec.Command(wkhtmltopdfPath, "-q", "-", "-")
+	cmd.Stdin = bytes.NewReader(html)
+
+	var out, stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("błąd podczas uruchamiania wkhtmltopdf: %v (%s)", err, stderr.String())
+	}
+
+	return out.Bytes(), nil
+}
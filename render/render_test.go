@@ -0,0 +1,27 @@
+package render
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/janexpl/epp2json"
+)
+
+// TestRenderInvoiceHTMLZeroDates sprawdza, że faktura bez daty sprzedaży (typowe
+// dla dokumentów WZ/PZ/PA) nie renderuje "0001-01-01" w wygenerowanym HTML-u
+func TestRenderInvoiceHTMLZeroDates(t *testing.T) {
+	inv := epp2json.Invoice{
+		Type:        "WZ",
+		Number:      "WZ/1/2024",
+		GrossAmount: 100,
+	}
+
+	html, err := RenderInvoiceHTML(inv, DefaultRenderOptions())
+	if err != nil {
+		t.Fatalf("RenderInvoiceHTML zwróciło błąd: %v", err)
+	}
+
+	if bytes.Contains(html, []byte("0001-01-01")) {
+		t.Fatalf("wygenerowany HTML zawiera zerową datę 0001-01-01:\n%s", html)
+	}
+}
@@ -0,0 +1,173 @@
+package epp2json
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+// Przestrzenie nazw UBL 2.1 wymagane, aby znaczniki cac:/cbc: były poprawnie
+// związane w wyemitowanym dokumencie XML
+const (
+	ublNamespaceInvoice    = "urn:oasis:names:specification:ubl:schema:xsd:Invoice-2"
+	ublNamespaceCreditNote = "urn:oasis:names:specification:ubl:schema:xsd:CreditNote-2"
+	ublNamespaceCAC        = "urn:oasis:names:specification:ubl:schema:xsd:CommonAggregateComponents-2"
+	ublNamespaceCBC        = "urn:oasis:names:specification:ubl:schema:xsd:CommonBasicComponents-2"
+)
+
+// UBLParty reprezentuje stronę (dostawcę lub nabywcę) w dokumencie UBL
+type UBLParty struct {
+	Name       string `xml:"cac:PartyName>cbc:Name"`
+	TaxID      string `xml:"cac:PartyTaxScheme>cbc:CompanyID,omitempty"`
+	Street     string `xml:"cac:PostalAddress>cbc:StreetName,omitempty"`
+	City       string `xml:"cac:PostalAddress>cbc:CityName,omitempty"`
+	PostalZone string `xml:"cac:PostalAddress>cbc:PostalZone,omitempty"`
+}
+
+// UBLLine reprezentuje pozycję faktury (InvoiceLine / CreditNoteLine) w dokumencie UBL
+type UBLLine struct {
+	ID                  string  `xml:"cbc:ID"`
+	Quantity            float64 `xml:"cbc:InvoicedQuantity"`
+	LineExtensionAmount float64 `xml:"cbc:LineExtensionAmount"`
+	ItemName            string  `xml:"cac:Item>cbc:Name"`
+	TaxPercent          float64 `xml:"cac:Item>cac:ClassifiedTaxCategory>cbc:Percent"`
+	PriceAmount         float64 `xml:"cac:Price>cbc:PriceAmount"`
+}
+
+// UBLMonetaryTotal reprezentuje podsumowanie kwot dokumentu (LegalMonetaryTotal)
+type UBLMonetaryTotal struct {
+	TaxExclusiveAmount float64 `xml:"cbc:TaxExclusiveAmount"`
+	TaxInclusiveAmount float64 `xml:"cbc:TaxInclusiveAmount"`
+	PayableAmount      float64 `xml:"cbc:PayableAmount"`
+}
+
+// UBLInvoice reprezentuje uproszczony dokument UBL 2.1 Invoice
+type UBLInvoice struct {
+	XMLName                 xml.Name         `xml:"Invoice"`
+	Xmlns                   string           `xml:"xmlns,attr"`
+	XmlnsCac                string           `xml:"xmlns:cac,attr"`
+	XmlnsCbc                string           `xml:"xmlns:cbc,attr"`
+	ID                      string           `xml:"cbc:ID"`
+	IssueDate               string           `xml:"cbc:IssueDate"`
+	DueDate                 string           `xml:"cbc:DueDate,omitempty"`
+	DocumentCurrencyCode    string           `xml:"cbc:DocumentCurrencyCode,omitempty"`
+	AccountingSupplierParty UBLParty         `xml:"cac:AccountingSupplierParty>cac:Party"`
+	AccountingCustomerParty UBLParty         `xml:"cac:AccountingCustomerParty>cac:Party"`
+	InvoiceLines            []UBLLine        `xml:"cac:InvoiceLine"`
+	LegalMonetaryTotal      UBLMonetaryTotal `xml:"cac:LegalMonetaryTotal"`
+}
+
+// UBLCreditNote reprezentuje uproszczony dokument UBL 2.1 CreditNote (faktura korygująca)
+type UBLCreditNote struct {
+	XMLName                 xml.Name         `xml:"CreditNote"`
+	Xmlns                   string           `xml:"xmlns,attr"`
+	XmlnsCac                string           `xml:"xmlns:cac,attr"`
+	XmlnsCbc                string           `xml:"xmlns:cbc,attr"`
+	ID                      string           `xml:"cbc:ID"`
+	IssueDate               string           `xml:"cbc:IssueDate"`
+	DueDate                 string           `xml:"cbc:DueDate,omitempty"`
+	DocumentCurrencyCode    string           `xml:"cbc:DocumentCurrencyCode,omitempty"`
+	AccountingSupplierParty UBLParty         `xml:"cac:AccountingSupplierParty>cac:Party"`
+	AccountingCustomerParty UBLParty         `xml:"cac:AccountingCustomerParty>cac:Party"`
+	CreditNoteLines         []UBLLine        `xml:"cac:CreditNoteLine"`
+	LegalMonetaryTotal      UBLMonetaryTotal `xml:"cac:LegalMonetaryTotal"`
+}
+
+// UBLDocuments opakowuje wszystkie faktury i korekty z pliku EPP w jeden dokument XML
+type UBLDocuments struct {
+	XMLName     xml.Name        `xml:"Documents"`
+	Invoices    []UBLInvoice    `xml:"Invoice"`
+	CreditNotes []UBLCreditNote `xml:"CreditNote"`
+}
+
+// mapPartiesUBL zwraca strony dostawcy i nabywcy w kolejności zgodnej z typem faktury:
+// dla FZ/KFZ dostawcą jest kontrahent, dla FS/KFS - nasza firma
+func mapPartiesUBL(invoice Invoice, company string) (supplier, customer UBLParty) {
+	contractor := UBLParty{
+		Name:       invoice.ContractorName,
+		TaxID:      invoice.NIP,
+		Street:     invoice.Address,
+		City:       invoice.City,
+		PostalZone: invoice.PostalCode,
+	}
+	us := UBLParty{Name: company}
+
+	switch invoice.Type {
+	case "FZ", "KFZ":
+		return contractor, us
+	default:
+		return us, contractor
+	}
+}
+
+// mapLinesUBL mapuje pozycje faktury EPP na pozycje UBL
+func mapLinesUBL(items []InvoiceItem) []UBLLine {
+	lines := make([]UBLLine, 0, len(items))
+	for i, item := range items {
+		lines = append(lines, UBLLine{
+			ID:                  fmt.Sprintf("%d", i+1),
+			Quantity:            item.Quantity,
+			LineExtensionAmount: item.NetTotal,
+			ItemName:            fmt.Sprintf("Pozycja VAT %s", item.VatRate),
+			TaxPercent:          parseVatPercent(item.VatRate),
+			PriceAmount:         item.NetPrice,
+		})
+	}
+	return lines
+}
+
+// ConvertEPPToUBL konwertuje plik EPP na dokumenty UBL 2.1: FZ/FS trafiają do
+// elementów Invoice, a KFZ/KFS (korekty) do elementów CreditNote
+func ConvertEPPToUBL(inputFile string, options ParseOptions) (xmlData []byte, err error) {
+	eppData, err := ParseEPPFile(inputFile, options)
+	if err != nil {
+		return nil, fmt.Errorf("błąd podczas parsowania pliku EPP: %v", err)
+	}
+
+	docs := UBLDocuments{}
+	for _, invoice := range eppData.Invoices {
+		supplier, customer := mapPartiesUBL(invoice, eppData.Info["company"])
+		total := UBLMonetaryTotal{
+			TaxExclusiveAmount: invoice.NetAmount,
+			TaxInclusiveAmount: invoice.GrossAmount,
+			PayableAmount:      invoice.GrossAmount,
+		}
+
+		switch invoice.Type {
+		case "KFZ", "KFS":
+			docs.CreditNotes = append(docs.CreditNotes, UBLCreditNote{
+				Xmlns:                   ublNamespaceCreditNote,
+				XmlnsCac:                ublNamespaceCAC,
+				XmlnsCbc:                ublNamespaceCBC,
+				ID:                      invoice.Number,
+				IssueDate:               formatGoBLDate(invoice.IssueDate),
+				DueDate:                 formatGoBLDate(invoice.PaymentDate),
+				DocumentCurrencyCode:    invoice.Currency,
+				AccountingSupplierParty: supplier,
+				AccountingCustomerParty: customer,
+				CreditNoteLines:         mapLinesUBL(invoice.Items),
+				LegalMonetaryTotal:      total,
+			})
+		default:
+			docs.Invoices = append(docs.Invoices, UBLInvoice{
+				Xmlns:                   ublNamespaceInvoice,
+				XmlnsCac:                ublNamespaceCAC,
+				XmlnsCbc:                ublNamespaceCBC,
+				ID:                      invoice.Number,
+				IssueDate:               formatGoBLDate(invoice.IssueDate),
+				DueDate:                 formatGoBLDate(invoice.PaymentDate),
+				DocumentCurrencyCode:    invoice.Currency,
+				AccountingSupplierParty: supplier,
+				AccountingCustomerParty: customer,
+				InvoiceLines:            mapLinesUBL(invoice.Items),
+				LegalMonetaryTotal:      total,
+			})
+		}
+	}
+
+	xmlData, err = xml.MarshalIndent(docs, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("błąd podczas konwersji do UBL: %v", err)
+	}
+
+	return append([]byte(xml.Header), xmlData...), nil
+}
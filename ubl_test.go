@@ -0,0 +1,22 @@
+package epp2json
+
+import "testing"
+
+// TestMapLinesUBLTaxPercent sprawdza, że stawki VAT zwolnione z podatku ("zw")
+// nie trafiają do cbc:Percent jako nieparsowalny tekst, tylko jako liczba
+// zgodna z typem xsd:decimal wymaganym przez schemat UBL
+func TestMapLinesUBLTaxPercent(t *testing.T) {
+	items := []InvoiceItem{
+		{VatRate: "23"},
+		{VatRate: "zw"},
+	}
+
+	lines := mapLinesUBL(items)
+
+	if lines[0].TaxPercent != 23 {
+		t.Errorf("TaxPercent dla stawki 23 = %v, chcemy 23", lines[0].TaxPercent)
+	}
+	if lines[1].TaxPercent != 0 {
+		t.Errorf("TaxPercent dla stawki zw = %v, chcemy 0", lines[1].TaxPercent)
+	}
+}
@@ -0,0 +1,129 @@
+package epp2json
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+const (
+	eppHeaderFieldCount = 47
+	eppItemFieldCount   = 8
+)
+
+// formatEPPDate formatuje czas do postaci YYYYMMDDHHMMSS oczekiwanej przez ParseDate,
+// zwracając pusty string dla daty zerowej
+func formatEPPDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("20060102150405")
+}
+
+// formatEPPFloat formatuje kwotę w postaci, którą ParseFloat odczyta bez utraty wartości
+func formatEPPFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// invoiceToFields odwraca ParseHeader, umieszczając pola faktury na tych samych
+// pozycjach, z których ParseHeader je odczytuje
+func invoiceToFields(invoice Invoice) []string {
+	fields := make([]string, eppHeaderFieldCount)
+	fields[0] = invoice.Type
+	fields[4] = invoice.Number
+	fields[6] = invoice.InternalNumber
+	fields[11] = invoice.ContractorCode
+	fields[12] = invoice.ContractorName
+	fields[13] = invoice.ContractorFullName
+	fields[14] = invoice.City
+	fields[15] = invoice.PostalCode
+	fields[16] = invoice.Address
+	fields[17] = invoice.NIP
+	fields[19] = invoice.Category
+	fields[21] = formatEPPDate(invoice.Date)
+	fields[22] = formatEPPDate(invoice.IssueDate)
+	fields[23] = formatEPPDate(invoice.SaleDate)
+	fields[27] = formatEPPFloat(invoice.NetAmount)
+	fields[28] = formatEPPFloat(invoice.VatAmount)
+	fields[29] = formatEPPFloat(invoice.GrossAmount)
+	fields[34] = formatEPPDate(invoice.PaymentDate)
+	fields[41] = invoice.Registrar
+	fields[46] = invoice.Currency
+	return fields
+}
+
+// itemToFields odwraca ParseItem, umieszczając pola pozycji na tych samych
+// pozycjach, z których ParseItem je odczytuje
+func itemToFields(item InvoiceItem) []string {
+	fields := make([]string, eppItemFieldCount)
+	fields[0] = item.VatRate
+	fields[1] = formatEPPFloat(item.Quantity)
+	fields[2] = formatEPPFloat(item.NetPrice)
+	fields[3] = formatEPPFloat(item.VatAmount)
+	fields[4] = formatEPPFloat(item.GrossPrice)
+	fields[5] = formatEPPFloat(item.NetTotal)
+	fields[6] = formatEPPFloat(item.VatTotal)
+	fields[7] = formatEPPFloat(item.GrossTotal)
+	return fields
+}
+
+// writeCSVLine zapisuje fields jako linię CSV ujętą w cudzysłów, zakończoną CRLF -
+// w formacie, który ParseCSVLine odczyta bez zmian
+func writeCSVLine(buf *bytes.Buffer, fields []string) error {
+	quoted := make([]string, len(fields))
+	for i, field := range fields {
+		quoted[i] = `"` + strings.ReplaceAll(field, `"`, `""`) + `"`
+	}
+	_, err := buf.WriteString(strings.Join(quoted, ",") + "\r\n")
+	return err
+}
+
+// MarshalEPP serializuje EPPData do formatu pliku EPP (Windows-1250, CRLF, pola CSV
+// ujęte w cudzysłów, sekcje [INFO]/[NAGLOWEK]/[ZAWARTOSC]) - odwrotność ParseEPPFromString
+func MarshalEPP(data *EPPData) ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteString("[INFO]\r\n")
+	infoFields := []string{
+		data.Info["version"], "", "", data.Info["system"], "", data.Info["company"],
+	}
+	if err := writeCSVLine(&buf, infoFields); err != nil {
+		return nil, fmt.Errorf("błąd podczas zapisu sekcji INFO: %v", err)
+	}
+
+	for _, invoice := range data.Invoices {
+		buf.WriteString("[NAGLOWEK]\r\n")
+		if err := writeCSVLine(&buf, invoiceToFields(invoice)); err != nil {
+			return nil, fmt.Errorf("błąd podczas zapisu nagłówka faktury %s: %v", invoice.Number, err)
+		}
+
+		buf.WriteString("[ZAWARTOSC]\r\n")
+		for _, item := range invoice.Items {
+			if err := writeCSVLine(&buf, itemToFields(item)); err != nil {
+				return nil, fmt.Errorf("błąd podczas zapisu pozycji faktury %s: %v", invoice.Number, err)
+			}
+		}
+	}
+
+	encoded, err := charmap.Windows1250.NewEncoder().Bytes(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("błąd podczas kodowania do Windows-1250: %v", err)
+	}
+
+	return encoded, nil
+}
+
+// WriteEPP zapisuje EPPData do w w formacie pliku EPP
+func WriteEPP(w io.Writer, data *EPPData) error {
+	encoded, err := MarshalEPP(data)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(encoded)
+	return err
+}
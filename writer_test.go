@@ -0,0 +1,57 @@
+package epp2json
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/transform"
+)
+
+const roundTripFixture = "[INFO]\r\n" +
+	"\"1.0\",\"\",\"\",\"TestSystem\",\"\",\"Test Sp. z o.o.\"\r\n" +
+	"[NAGLOWEK]\r\n" +
+	"\"FZ\",\"\",\"\",\"\",\"FZ/1/2024\",\"\",\"WEW-1\",\"\",\"\",\"\",\"\"," +
+	"\"K001\",\"Kontrahent Sp. z o.o.\",\"Kontrahent Sp. z o.o. Pelna Nazwa\"," +
+	"\"Warszawa\",\"00-001\",\"ul. Testowa 1\",\"1234567890\",\"\",\"KAT001\",\"\"," +
+	"\"20240115000000\",\"20240115000000\",\"20240110000000\",\"\",\"\",\"\"," +
+	"\"100.5\",\"23.12\",\"123.62\"\r\n" +
+	"[ZAWARTOSC]\r\n" +
+	"\"23\",\"2\",\"50.25\",\"11.56\",\"61.81\",\"100.5\",\"23.12\",\"123.62\"\r\n" +
+	"\"8\",\"1\",\"10\",\"0.8\",\"10.8\",\"10\",\"0.8\",\"10.8\"\r\n"
+
+// TestRoundTrip sprawdza, że dane sparsowane z pliku EPP, zapisane przez WriteEPP
+// i ponownie sparsowane dają dokładnie taki sam EPPData
+func TestRoundTrip(t *testing.T) {
+	options := DefaultParseOptions()
+
+	original, err := ParseEPPFromString(roundTripFixture, options)
+	if err != nil {
+		t.Fatalf("nie udało się sparsować fixture: %v", err)
+	}
+	if len(original.Invoices) != 1 || len(original.Invoices[0].Items) != 2 {
+		t.Fatalf("fixture powinien dać 1 fakturę z 2 pozycjami, otrzymano: %+v", original.Invoices)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteEPP(&buf, original); err != nil {
+		t.Fatalf("WriteEPP zwróciło błąd: %v", err)
+	}
+
+	decoder := transform.NewReader(&buf, charmap.Windows1250.NewDecoder())
+	decodedBytes, err := io.ReadAll(decoder)
+	if err != nil {
+		t.Fatalf("błąd podczas dekodowania zapisanego pliku: %v", err)
+	}
+
+	roundTripped, err := ParseEPPFromString(string(decodedBytes), options)
+	if err != nil {
+		t.Fatalf("nie udało się ponownie sparsować zapisanego pliku: %v", err)
+	}
+
+	if !reflect.DeepEqual(original, roundTripped) {
+		t.Fatalf("dane po round-tripie różnią się od oryginału:\noryginał: %+v\npo zapisie: %+v", original, roundTripped)
+	}
+}